@@ -502,5 +502,5 @@ func urlPort(urlWithPort string) string {
 func toSwarmURL(hostURL string, swarmHost string) string {
 	hostPort := urlPort(hostURL)
 	swarmPort := urlPort(swarmHost)
-	return strings.Replace(hostURL, ":"+hostPort, ":"+swarmPort, 1)
+	return strings.TrimSuffix(hostURL, ":"+hostPort) + ":" + swarmPort
 }
@@ -456,6 +456,21 @@ func TestIsSwarmActive(t *testing.T) {
 	}
 }
 
+func TestToSwarmURL(t *testing.T) {
+	cases := []struct {
+		hostURL  string
+		expected string
+	}{
+		{"tcp://1.2.3.4:2376", "tcp://1.2.3.4:3376"},
+		{"tcp://[2001:db8::1]:2376", "tcp://[2001:db8::1]:3376"},
+		{"tcp://[2001:db8::2376]:2376", "tcp://[2001:db8::2376]:3376"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, toSwarmURL(c.hostURL, "tcp://0.0.0.0:3376"))
+	}
+}
+
 func TestGetHostStateTimeout(t *testing.T) {
 	hosts := []*host.Host{
 		{
@@ -48,6 +48,10 @@ const (
 const (
 	keypairNotFoundCode             = "InvalidKeyPair.NotFound"
 	spotInstanceRequestNotFoundCode = "InvalidSpotInstanceRequestID.NotFound"
+	spotInstanceTerminationCode     = "Server.SpotInstanceTermination"
+	invalidAMIIDCodePrefix          = "InvalidAMIID."
+	unauthorizedOperationCode       = "UnauthorizedOperation"
+	amiNotFoundFormat               = "There is no AMI with the id %s in region %s. Please verify the AMI provided."
 )
 
 var (
@@ -58,6 +62,9 @@ var (
 	errorNoVPCIdFound                    = errors.New("amazonec2 driver requires either the --amazonec2-subnet-id or --amazonec2-vpc-id option or an AWS Account with a default vpc-id")
 	errorNoSubnetsFound                  = errors.New("The desired subnet could not be located in this region. Is '--amazonec2-subnet-id' or AWS_SUBNET_ID configured correctly?")
 	errorDisableSSLWithoutCustomEndpoint = errors.New("using --amazonec2-insecure-transport also requires --amazonec2-endpoint")
+	errorSecurityGroupCIDRWithDefault    = errors.New("using --amazonec2-security-group-cidr also requires a --amazonec2-security-group other than the shared " + defaultSecurityGroup + " group")
+	errorSecurityGroupCIDRWithReadOnly   = errors.New("using --amazonec2-security-group-cidr conflicts with --amazonec2-security-group-readonly, which adds no rules")
+	errorSpotInstanceInterrupted         = errors.New("spot instance was interrupted by AWS and terminated, the machine must be recreated")
 	errorReadingUserData                 = errors.New("unable to read --amazonec2-userdata file")
 )
 
@@ -88,6 +95,7 @@ type Driver struct {
 	SecurityGroupNames []string
 
 	SecurityGroupReadOnly   bool
+	SecurityGroupCIDR       string
 	OpenPorts               []string
 	Tags                    string
 	ReservationId           string
@@ -168,6 +176,12 @@ func (d *Driver) GetCreateFlags() []mcnflag.Flag {
 			Usage:  "Skip adding default rules to security groups",
 			EnvVar: "AWS_SECURITY_GROUP_READONLY",
 		},
+		mcnflag.StringFlag{
+			Name:   "amazonec2-security-group-cidr",
+			Usage:  "Source CIDR allowed by the default rules added to security groups",
+			Value:  ipRange,
+			EnvVar: "AWS_SECURITY_GROUP_CIDR",
+		},
 		mcnflag.StringSliceFlag{
 			Name:   "amazonec2-security-group",
 			Usage:  "AWS VPC security group",
@@ -298,6 +312,7 @@ func NewDriver(hostName, storePath string) *Driver {
 		RootSize:             defaultRootSize,
 		Zone:                 defaultZone,
 		SecurityGroupNames:   []string{defaultSecurityGroup},
+		SecurityGroupCIDR:    ipRange,
 		SpotPrice:            defaultSpotPrice,
 		BlockDurationMinutes: defaultBlockDurationMinutes,
 		BaseDriver: &drivers.BaseDriver{
@@ -363,6 +378,7 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 	d.SubnetId = flags.String("amazonec2-subnet-id")
 	d.SecurityGroupNames = flags.StringSlice("amazonec2-security-group")
 	d.SecurityGroupReadOnly = flags.Bool("amazonec2-security-group-readonly")
+	d.SecurityGroupCIDR = flags.String("amazonec2-security-group-cidr")
 	d.Tags = flags.String("amazonec2-tags")
 	zone := flags.String("amazonec2-zone")
 	d.Zone = zone[:]
@@ -394,6 +410,34 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 		return errorNoPrivateSSHKey
 	}
 
+	if d.SecurityGroupCIDR == "" {
+		d.SecurityGroupCIDR = ipRange
+	}
+
+	ip, ipNet, err := net.ParseCIDR(d.SecurityGroupCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid --amazonec2-security-group-cidr %q: %s", d.SecurityGroupCIDR, err)
+	}
+	if _, bits := ipNet.Mask.Size(); ip.To4() == nil || bits != 32 {
+		return fmt.Errorf("invalid --amazonec2-security-group-cidr %q: only IPv4 ranges are supported", d.SecurityGroupCIDR)
+	}
+	// AWS stores the network address, so compare existing rules against it.
+	d.SecurityGroupCIDR = ipNet.String()
+
+	if d.SecurityGroupReadOnly && d.SecurityGroupCIDR != ipRange {
+		return errorSecurityGroupCIDRWithReadOnly
+	}
+
+	// The default group is shared by every machine in the VPC, so restricting
+	// it for one machine would silently restrict it for all later ones.
+	if d.SecurityGroupCIDR != ipRange {
+		for _, name := range d.SecurityGroupNames {
+			if name == defaultSecurityGroup {
+				return errorSecurityGroupCIDRWithDefault
+			}
+		}
+	}
+
 	_, err = d.awsCredentialsFactory().Credentials().Get()
 	if err != nil {
 		return errorMissingCredentials
@@ -440,8 +484,12 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 			return fmt.Errorf("error parsing swarm host: %s", err)
 		}
 
-		parts := strings.Split(u.Host, ":")
-		port, err := strconv.Atoi(parts[1])
+		_, p, err := net.SplitHostPort(u.Host)
+		if err != nil {
+			return fmt.Errorf("error parsing swarm host: %s", err)
+		}
+
+		port, err := strconv.Atoi(p)
 		if err != nil {
 			return err
 		}
@@ -457,7 +505,37 @@ func (d *Driver) DriverName() string {
 	return driverName
 }
 
+func (d *Driver) checkAMI() error {
+	images, err := d.getClient().DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{&d.AMI},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if strings.HasPrefix(awsErr.Code(), invalidAMIIDCodePrefix) {
+				return fmt.Errorf(amiNotFoundFormat, d.AMI, d.Region)
+			}
+			// The lookup is only advisory, so don't make ec2:DescribeImages a
+			// required permission.
+			if awsErr.Code() == unauthorizedOperationCode {
+				log.Warnf("Couldn't verify that AMI %s exists in region %s: %s", d.AMI, d.Region, awsErr.Message())
+				return nil
+			}
+		}
+		return err
+	}
+
+	if len(images.Images) == 0 {
+		return fmt.Errorf(amiNotFoundFormat, d.AMI, d.Region)
+	}
+
+	return nil
+}
+
 func (d *Driver) checkPrereqs() error {
+	if err := d.checkAMI(); err != nil {
+		return err
+	}
+
 	// check for existing keypair
 	keyName := d.KeyName
 	keyShouldExist := true
@@ -833,6 +911,9 @@ func (d *Driver) GetState() (state.State, error) {
 	case ec2.InstanceStateNameStopped:
 		return state.Stopped, nil
 	case ec2.InstanceStateNameTerminated:
+		if inst.StateReason != nil && aws.StringValue(inst.StateReason.Code) == spotInstanceTerminationCode {
+			return state.Error, errorSpotInstanceInterrupted
+		}
 		return state.Error, nil
 	default:
 		log.Warnf("unrecognized instance state: %v", *inst.State.Name)
@@ -1169,38 +1250,56 @@ func (d *Driver) configureSecurityGroupPermissions(group *ec2.SecurityGroup) ([]
 		return nil, nil
 	}
 	hasPorts := make(map[string]bool)
+	portRanges := make(map[string][]string)
 	for _, p := range group.IpPermissions {
 		if p.FromPort != nil {
-			hasPorts[fmt.Sprintf("%d/%s", *p.FromPort, *p.IpProtocol)] = true
+			key := fmt.Sprintf("%d/%s", *p.FromPort, *p.IpProtocol)
+			hasPorts[key] = true
+			for _, r := range p.IpRanges {
+				if r.CidrIp != nil {
+					portRanges[key] = append(portRanges[key], *r.CidrIp)
+				}
+			}
 		}
 	}
 
 	perms := []*ec2.IpPermission{}
 
+	if err := d.checkExistingRanges(group, fmt.Sprintf("%d/tcp", d.BaseDriver.SSHPort), portRanges); err != nil {
+		return nil, err
+	}
 	if !hasPorts[fmt.Sprintf("%d/tcp", d.BaseDriver.SSHPort)] {
 		perms = append(perms, &ec2.IpPermission{
 			IpProtocol: aws.String("tcp"),
 			FromPort:   aws.Int64(int64(d.BaseDriver.SSHPort)),
 			ToPort:     aws.Int64(int64(d.BaseDriver.SSHPort)),
-			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(ipRange)}},
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(d.SecurityGroupCIDR)}},
 		})
 	}
 
+	if err := d.checkExistingRanges(group, fmt.Sprintf("%d/tcp", dockerPort), portRanges); err != nil {
+		return nil, err
+	}
 	if !hasPorts[fmt.Sprintf("%d/tcp", dockerPort)] {
 		perms = append(perms, &ec2.IpPermission{
 			IpProtocol: aws.String("tcp"),
 			FromPort:   aws.Int64(int64(dockerPort)),
 			ToPort:     aws.Int64(int64(dockerPort)),
-			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(ipRange)}},
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(d.SecurityGroupCIDR)}},
 		})
 	}
 
+	if d.SwarmMaster {
+		if err := d.checkExistingRanges(group, fmt.Sprintf("%d/tcp", swarmPort), portRanges); err != nil {
+			return nil, err
+		}
+	}
 	if !hasPorts[fmt.Sprintf("%d/tcp", swarmPort)] && d.SwarmMaster {
 		perms = append(perms, &ec2.IpPermission{
 			IpProtocol: aws.String("tcp"),
 			FromPort:   aws.Int64(int64(swarmPort)),
 			ToPort:     aws.Int64(int64(swarmPort)),
-			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(ipRange)}},
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(d.SecurityGroupCIDR)}},
 		})
 	}
 
@@ -1210,21 +1309,40 @@ func (d *Driver) configureSecurityGroupPermissions(group *ec2.SecurityGroup) ([]
 		if err != nil {
 			return nil, fmt.Errorf("invalid port number %s: %s", port, err)
 		}
+		if err := d.checkExistingRanges(group, fmt.Sprintf("%s/%s", port, protocol), portRanges); err != nil {
+			return nil, err
+		}
 		if !hasPorts[fmt.Sprintf("%s/%s", port, protocol)] {
 			perms = append(perms, &ec2.IpPermission{
 				IpProtocol: aws.String(protocol),
 				FromPort:   aws.Int64(portNum),
 				ToPort:     aws.Int64(portNum),
-				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(ipRange)}},
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(d.SecurityGroupCIDR)}},
 			})
 		}
 	}
 
-	log.Debugf("configuring security group authorization for %s", ipRange)
+	log.Debugf("configuring security group authorization for %s", d.SecurityGroupCIDR)
 
 	return perms, nil
 }
 
+// checkExistingRanges fails when the group already opens port to a source
+// other than --amazonec2-security-group-cidr. The driver never adds a rule
+// for a port the group already has, so the requested restriction would
+// otherwise be silently ignored.
+func (d *Driver) checkExistingRanges(group *ec2.SecurityGroup, port string, portRanges map[string][]string) error {
+	if d.SecurityGroupCIDR == ipRange {
+		return nil
+	}
+	for _, cidr := range portRanges[port] {
+		if cidr != d.SecurityGroupCIDR {
+			return fmt.Errorf("security group %s already allows %s from %s, which is not restricted to --amazonec2-security-group-cidr %s", aws.StringValue(group.GroupName), port, cidr, d.SecurityGroupCIDR)
+		}
+	}
+	return nil
+}
+
 func (d *Driver) deleteKeyPair() error {
 	if d.KeyName == "" {
 		log.Warn("Missing key pair name, this is likely due to a failure during machine creation")
@@ -11,8 +11,10 @@ import (
 	"path/filepath"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/docker/machine/commands/commandstest"
+	"github.com/docker/machine/libmachine/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -98,6 +100,66 @@ func TestConfigureSecurityGroupPermissionsDockerAndSsh(t *testing.T) {
 	assert.Empty(t, perms)
 }
 
+func TestConfigureSecurityGroupPermissionsCustomCIDR(t *testing.T) {
+	driver := NewTestDriver()
+	driver.SecurityGroupCIDR = "10.0.0.0/16"
+	group := securityGroup
+	group.IpPermissions = []*ec2.IpPermission{}
+
+	perms, err := driver.configureSecurityGroupPermissions(group)
+
+	assert.Nil(t, err)
+	assert.Len(t, perms, 2)
+	for _, perm := range perms {
+		assert.Equal(t, []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}}, perm.IpRanges)
+	}
+}
+
+func TestConfigureSecurityGroupPermissionsCustomCIDRWithWiderRule(t *testing.T) {
+	driver := NewTestDriver()
+	driver.SecurityGroupCIDR = "10.0.0.0/16"
+	group := securityGroup
+	group.IpPermissions = []*ec2.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(testSSHPort),
+			ToPort:     aws.Int64(testSSHPort),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		},
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(testDockerPort),
+			ToPort:     aws.Int64(testDockerPort),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		},
+	}
+
+	perms, err := driver.configureSecurityGroupPermissions(group)
+
+	assert.EqualError(t, err, "security group test-group already allows 22/tcp from 0.0.0.0/0, which is not restricted to --amazonec2-security-group-cidr 10.0.0.0/16")
+	assert.Nil(t, perms)
+}
+
+func TestConfigureSecurityGroupPermissionsCustomCIDRWithMatchingRule(t *testing.T) {
+	driver := NewTestDriver()
+	driver.SecurityGroupCIDR = "10.0.0.0/16"
+	group := securityGroup
+	group.IpPermissions = []*ec2.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(testSSHPort),
+			ToPort:     aws.Int64(testSSHPort),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}},
+		},
+	}
+
+	perms, err := driver.configureSecurityGroupPermissions(group)
+
+	assert.Nil(t, err)
+	assert.Len(t, perms, 1)
+	assert.Equal(t, testDockerPort, *perms[0].FromPort)
+}
+
 func TestConfigureSecurityGroupPermissionsSkipReadOnly(t *testing.T) {
 	driver := NewTestDriver()
 	driver.SecurityGroupReadOnly = true
@@ -252,6 +314,33 @@ func TestDefaultVPCIsNone(t *testing.T) {
 	assert.Empty(t, vpc)
 }
 
+func TestGetStateTerminated(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithInstance{
+		instance: &ec2.Instance{
+			State: &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameTerminated)},
+		},
+	})
+
+	st, err := driver.GetState()
+
+	assert.Equal(t, state.Error, st)
+	assert.NoError(t, err)
+}
+
+func TestGetStateSpotInstanceInterrupted(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithInstance{
+		instance: &ec2.Instance{
+			State:       &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameTerminated)},
+			StateReason: &ec2.StateReason{Code: aws.String(spotInstanceTerminationCode)},
+		},
+	})
+
+	st, err := driver.GetState()
+
+	assert.Equal(t, state.Error, st)
+	assert.Equal(t, errorSpotInstanceInterrupted, err)
+}
+
 func TestGetRegionZoneForDefaultEndpoint(t *testing.T) {
 	driver := NewCustomTestDriver(&fakeEC2WithLogin{})
 	driver.awsCredentialsFactory = NewValidAwsCredentials
@@ -305,6 +394,58 @@ func TestDescribeAccountAttributeFails(t *testing.T) {
 	assert.Empty(t, vpc)
 }
 
+func TestCheckAMI(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithImages{
+		output: &ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{{ImageId: aws.String(defaultAmiId)}},
+		},
+	})
+
+	err := driver.checkAMI()
+
+	assert.NoError(t, err)
+}
+
+func TestCheckAMINotFound(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithImages{
+		err: awserr.New("InvalidAMIID.NotFound", "The image id '[ami-c60b90d1]' does not exist", nil),
+	})
+
+	err := driver.checkAMI()
+
+	assert.EqualError(t, err, "There is no AMI with the id ami-c60b90d1 in region us-east-1. Please verify the AMI provided.")
+}
+
+func TestCheckAMIEmptyResult(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithImages{
+		output: &ec2.DescribeImagesOutput{},
+	})
+
+	err := driver.checkAMI()
+
+	assert.EqualError(t, err, "There is no AMI with the id ami-c60b90d1 in region us-east-1. Please verify the AMI provided.")
+}
+
+func TestCheckAMIFails(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithImages{
+		err: errors.New("Unauthorized"),
+	})
+
+	err := driver.checkAMI()
+
+	assert.EqualError(t, err, "Unauthorized")
+}
+
+func TestCheckAMIUnauthorized(t *testing.T) {
+	driver := NewCustomTestDriver(&fakeEC2WithImages{
+		err: awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil),
+	})
+
+	err := driver.checkAMI()
+
+	assert.NoError(t, err)
+}
+
 func TestAwsCredentialsAreRequired(t *testing.T) {
 	driver := NewTestDriver()
 	driver.awsCredentialsFactory = NewErrorAwsCredentials
@@ -354,6 +495,100 @@ func TestEndpointIsMandatoryWhenSSLDisabled(t *testing.T) {
 	assert.Equal(t, err, errorDisableSSLWithoutCustomEndpoint)
 }
 
+func TestInvalidSecurityGroupCIDR(t *testing.T) {
+	driver := NewTestDriver()
+	driver.awsCredentialsFactory = NewValidAwsCredentials
+	options := &commandstest.FakeFlagger{
+		Data: map[string]interface{}{
+			"name":                          "test",
+			"amazonec2-region":              "us-east-1",
+			"amazonec2-zone":                "e",
+			"amazonec2-security-group-cidr": "10.0.0.0",
+		},
+	}
+
+	err := driver.SetConfigFromFlags(options)
+
+	assert.EqualError(t, err, `invalid --amazonec2-security-group-cidr "10.0.0.0": invalid CIDR address: 10.0.0.0`)
+}
+
+func TestIPv6SecurityGroupCIDR(t *testing.T) {
+	for _, cidr := range []string{"::/0", "2001:db8::/32", "::ffff:10.0.0.0/104"} {
+		driver := NewTestDriver()
+		driver.awsCredentialsFactory = NewValidAwsCredentials
+		options := &commandstest.FakeFlagger{
+			Data: map[string]interface{}{
+				"name":                          "test",
+				"amazonec2-region":              "us-east-1",
+				"amazonec2-zone":                "e",
+				"amazonec2-security-group":      []string{"restricted"},
+				"amazonec2-security-group-cidr": cidr,
+			},
+		}
+
+		err := driver.SetConfigFromFlags(options)
+
+		assert.EqualError(t, err, `invalid --amazonec2-security-group-cidr "`+cidr+`": only IPv4 ranges are supported`)
+	}
+}
+
+func TestSecurityGroupCIDRIsCanonicalized(t *testing.T) {
+	driver := NewTestDriver()
+	driver.awsCredentialsFactory = NewValidAwsCredentials
+	options := &commandstest.FakeFlagger{
+		Data: map[string]interface{}{
+			"name":                          "test",
+			"amazonec2-region":              "us-east-1",
+			"amazonec2-zone":                "e",
+			"amazonec2-vpc-id":              "vpc-12345",
+			"amazonec2-security-group":      []string{"restricted"},
+			"amazonec2-security-group-cidr": "10.0.0.5/16",
+		},
+	}
+
+	err := driver.SetConfigFromFlags(options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/16", driver.SecurityGroupCIDR)
+}
+
+func TestSecurityGroupCIDRConflictsWithReadOnly(t *testing.T) {
+	driver := NewTestDriver()
+	driver.awsCredentialsFactory = NewValidAwsCredentials
+	options := &commandstest.FakeFlagger{
+		Data: map[string]interface{}{
+			"name":                              "test",
+			"amazonec2-region":                  "us-east-1",
+			"amazonec2-zone":                    "e",
+			"amazonec2-security-group":          []string{"restricted"},
+			"amazonec2-security-group-readonly": true,
+			"amazonec2-security-group-cidr":     "10.0.0.0/16",
+		},
+	}
+
+	err := driver.SetConfigFromFlags(options)
+
+	assert.Equal(t, errorSecurityGroupCIDRWithReadOnly, err)
+}
+
+func TestSecurityGroupCIDRRequiresCustomGroup(t *testing.T) {
+	driver := NewTestDriver()
+	driver.awsCredentialsFactory = NewValidAwsCredentials
+	options := &commandstest.FakeFlagger{
+		Data: map[string]interface{}{
+			"name":                          "test",
+			"amazonec2-region":              "us-east-1",
+			"amazonec2-zone":                "e",
+			"amazonec2-security-group":      []string{defaultSecurityGroup},
+			"amazonec2-security-group-cidr": "10.0.0.0/16",
+		},
+	}
+
+	err := driver.SetConfigFromFlags(options)
+
+	assert.Equal(t, errorSecurityGroupCIDRWithDefault, err)
+}
+
 var values = []string{
 	"bob",
 	"jake",
@@ -97,6 +97,29 @@ func (f *fakeEC2WithLogin) DescribeAccountAttributes(input *ec2.DescribeAccountA
 	}, nil
 }
 
+type fakeEC2WithInstance struct {
+	*fakeEC2
+	instance *ec2.Instance
+}
+
+func (f *fakeEC2WithInstance) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{Instances: []*ec2.Instance{f.instance}},
+		},
+	}, nil
+}
+
+type fakeEC2WithImages struct {
+	*fakeEC2
+	output *ec2.DescribeImagesOutput
+	err    error
+}
+
+func (f *fakeEC2WithImages) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	return f.output, f.err
+}
+
 type fakeEC2SecurityGroupTestRecorder struct {
 	*fakeEC2
 	mock.Mock
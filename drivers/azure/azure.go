@@ -56,6 +56,7 @@ const (
 	flAzureCustomData      = "azure-custom-data"
 	flAzureClientID        = "azure-client-id"
 	flAzureClientSecret    = "azure-client-secret"
+	flAzureTags            = "azure-tag"
 )
 
 const (
@@ -91,6 +92,7 @@ type Driver struct {
 	DNSLabel       string
 	StaticPublicIP bool
 	CustomDataFile string
+	Tags           []string
 
 	// Ephemeral fields
 	ctx        *azureutil.DeploymentContext
@@ -223,6 +225,10 @@ func (d *Driver) GetCreateFlags() []mcnflag.Flag {
 			Name:  flAzurePorts,
 			Usage: "Make the specified port number accessible from the Internet",
 		},
+		mcnflag.StringSliceFlag{
+			Name:  flAzureTags,
+			Usage: "Tag to set on the virtual machine. Format: key=value",
+		},
 		mcnflag.StringFlag{
 			Name:   flAzureClientID,
 			Usage:  "Azure Service Principal Account ID (optional, browser auth is used if not specified)",
@@ -276,6 +282,10 @@ func (d *Driver) SetConfigFromFlags(fl drivers.DriverOptions) error {
 	d.DockerPort = fl.Int(flAzureDockerPort)
 	d.DNSLabel = fl.String(flAzureDNSLabel)
 	d.CustomDataFile = fl.String(flAzureCustomData)
+	d.Tags = fl.StringSlice(flAzureTags)
+	if _, err := parseTags(d.Tags); err != nil {
+		return err
+	}
 
 	d.ClientID = fl.String(flAzureClientID)
 	d.ClientSecret = fl.String(flAzureClientSecret)
@@ -348,6 +358,11 @@ func (d *Driver) Create() error {
 		return err
 	}
 
+	tags, err := parseTags(d.Tags)
+	if err != nil {
+		return err
+	}
+
 	var customData string
 	if d.CustomDataFile != "" {
 		buf, err := ioutil.ReadFile(d.CustomDataFile)
@@ -391,7 +406,7 @@ func (d *Driver) Create() error {
 		return err
 	}
 	err = c.CreateVirtualMachine(d.ResourceGroup, d.naming().VM(), d.Location, d.Size, d.ctx.AvailabilitySetID,
-		d.ctx.NetworkInterfaceID, d.BaseDriver.SSHUser, d.ctx.SSHPublicKey, d.Image, customData, d.ctx.StorageAccount)
+		d.ctx.NetworkInterfaceID, d.BaseDriver.SSHUser, d.ctx.SSHPublicKey, d.Image, customData, tags, d.ctx.StorageAccount)
 	return err
 }
 
@@ -497,7 +497,7 @@ func (a AzureClient) removeOSDiskBlob(resourceGroup, vmName, vhdURL string) erro
 }
 
 func (a AzureClient) CreateVirtualMachine(resourceGroup, name, location, size, availabilitySetID, networkInterfaceID,
-	username, sshPublicKey, imageName, customData string, storageAccount *storage.AccountProperties) error {
+	username, sshPublicKey, imageName, customData string, tags *map[string]*string, storageAccount *storage.AccountProperties) error {
 	log.Info("Creating virtual machine.", logutil.Fields{
 		"name":     name,
 		"location": location,
@@ -541,6 +541,7 @@ func (a AzureClient) CreateVirtualMachine(resourceGroup, name, location, size, a
 	_, err = a.virtualMachinesClient().CreateOrUpdate(resourceGroup, name,
 		compute.VirtualMachine{
 			Location: to.StringPtr(location),
+			Tags:     tags,
 			Properties: &compute.VirtualMachineProperties{
 				AvailabilitySet: &compute.SubResource{
 					ID: to.StringPtr(availabilitySetID),
@@ -228,3 +228,33 @@ func parseSecurityRuleProtocol(proto string) (network.SecurityRuleProtocol, erro
 		return "", fmt.Errorf("invalid protocol %s", proto)
 	}
 }
+
+// parseTags parses virtual machine tags in "key=value" format. Azure limits
+// tag names to 512 characters, values to 256 characters and forbids some
+// characters in names.
+func parseTags(tags []string) (*map[string]*string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[string]*string, len(tags))
+	for _, tag := range tags {
+		l := strings.SplitN(tag, "=", 2)
+		if len(l) != 2 || l[0] == "" {
+			return nil, fmt.Errorf("invalid tag %q, expected key=value", tag)
+		}
+		key, value := l[0], l[1]
+		if len(key) > 512 || strings.ContainsAny(key, `<>%&\?/`) {
+			return nil, fmt.Errorf("invalid tag name %q, names must be at most 512 characters and must not contain <, >, %%, &, \\, ? or /", key)
+		}
+		if len(value) > 256 {
+			return nil, fmt.Errorf("invalid tag value for %q, values must be at most 256 characters", key)
+		}
+		if _, ok := parsed[key]; ok {
+			return nil, fmt.Errorf("duplicate tag %q", key)
+		}
+		parsed[key] = to.StringPtr(value)
+	}
+
+	return &parsed, nil
+}
@@ -1,9 +1,11 @@
 package azure
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,3 +31,41 @@ func TestParseSecurityRuleProtocol(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTags(t *testing.T) {
+	tags, err := parseTags([]string{"team=infra", "cost-center=42", "empty="})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]*string{
+		"team":        to.StringPtr("infra"),
+		"cost-center": to.StringPtr("42"),
+		"empty":       to.StringPtr(""),
+	}, tags)
+}
+
+func TestParseNoTags(t *testing.T) {
+	tags, err := parseTags(nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+}
+
+func TestParseInvalidTags(t *testing.T) {
+	tests := []struct {
+		tags        []string
+		expectedErr string
+	}{
+		{[]string{"team"}, `invalid tag "team", expected key=value`},
+		{[]string{"=infra"}, `invalid tag "=infra", expected key=value`},
+		{[]string{"a/b=infra"}, `invalid tag name "a/b", names must be at most 512 characters and must not contain <, >, %, &, \, ? or /`},
+		{[]string{strings.Repeat("k", 513) + "=infra"}, `invalid tag name "` + strings.Repeat("k", 513) + `", names must be at most 512 characters and must not contain <, >, %, &, \, ? or /`},
+		{[]string{"team=" + strings.Repeat("v", 257)}, `invalid tag value for "team", values must be at most 256 characters`},
+		{[]string{"team=a", "team=b"}, `duplicate tag "team"`},
+	}
+
+	for _, tc := range tests {
+		tags, err := parseTags(tc.tags)
+		assert.EqualError(t, err, tc.expectedErr)
+		assert.Nil(t, tags)
+	}
+}
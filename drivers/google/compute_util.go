@@ -3,6 +3,7 @@ package google
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -48,6 +49,11 @@ const (
 	firewallTargetTag = "docker-machine"
 )
 
+var (
+	labelKeyRegexp   = regexp.MustCompile("^[a-z][a-z0-9_-]{0,62}$")
+	labelValueRegexp = regexp.MustCompile("^[a-z0-9_-]{0,63}$")
+)
+
 // NewComputeUtil creates and initializes a ComputeUtil.
 func newComputeUtil(driver *Driver) (*ComputeUtil, error) {
 	client, err := google.DefaultClient(oauth2.NoContext, raw.ComputeScope)
@@ -169,7 +175,10 @@ func (c *ComputeUtil) portsUsed() ([]string, error) {
 			return nil, fmt.Errorf("error authorizing port for swarm: %s", err)
 		}
 
-		swarmPort := strings.Split(u.Host, ":")[1]
+		_, swarmPort, err := net.SplitHostPort(u.Host)
+		if err != nil {
+			return nil, fmt.Errorf("error authorizing port for swarm: %s", err)
+		}
 		ports = append(ports, swarmPort+"/tcp")
 	}
 	for _, p := range c.openPorts {
@@ -236,6 +245,11 @@ func (c *ComputeUtil) instance() (*raw.Instance, error) {
 func (c *ComputeUtil) createInstance(d *Driver) error {
 	log.Infof("Creating instance")
 
+	labels, err := parseLabels(d)
+	if err != nil {
+		return err
+	}
+
 	var net string
 	if strings.Contains(d.Network, "/networks/") {
 		net = d.Network
@@ -263,6 +277,7 @@ func (c *ComputeUtil) createInstance(d *Driver) error {
 		Tags: &raw.Tags{
 			Items: parseTags(d),
 		},
+		Labels: labels,
 		ServiceAccounts: []*raw.ServiceAccount{
 			{
 				Email:  d.ServiceAccount,
@@ -399,6 +414,32 @@ func parseTags(d *Driver) []string {
 	return tags
 }
 
+func parseLabels(d *Driver) (map[string]string, error) {
+	if len(d.Labels) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(d.Labels))
+	for _, label := range d.Labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid GCE label %q, expected key=value", label)
+		}
+		if !labelKeyRegexp.MatchString(parts[0]) {
+			return nil, fmt.Errorf("invalid GCE label key %q, keys must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores or dashes", parts[0])
+		}
+		if !labelValueRegexp.MatchString(parts[1]) {
+			return nil, fmt.Errorf("invalid GCE label value %q, values must contain at most 63 lowercase letters, digits, underscores or dashes", parts[1])
+		}
+		if _, ok := labels[parts[0]]; ok {
+			return nil, fmt.Errorf("duplicate GCE label key %q", parts[0])
+		}
+		labels[parts[0]] = parts[1]
+	}
+
+	return labels, nil
+}
+
 // deleteInstance deletes the instance, leaving the persistent disk.
 func (c *ComputeUtil) deleteInstance() error {
 	log.Infof("Deleting instance.")
@@ -1,6 +1,7 @@
 package google
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,64 @@ func TestAdditionalTags(t *testing.T) {
 	assert.Equal(t, []string{"docker-machine", "tag1", "tag2"}, tags)
 }
 
+func TestNoLabels(t *testing.T) {
+	labels, err := parseLabels(&Driver{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, labels)
+}
+
+func TestLabels(t *testing.T) {
+	labels, err := parseLabels(&Driver{Labels: []string{"team=infra", "cost-center=42", "empty="}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "infra", "cost-center": "42", "empty": ""}, labels)
+}
+
+func TestInvalidLabel(t *testing.T) {
+	labels, err := parseLabels(&Driver{Labels: []string{"team"}})
+
+	assert.EqualError(t, err, `invalid GCE label "team", expected key=value`)
+	assert.Nil(t, labels)
+}
+
+func TestDuplicateLabel(t *testing.T) {
+	labels, err := parseLabels(&Driver{Labels: []string{"team=a", "team=b"}})
+
+	assert.EqualError(t, err, `duplicate GCE label key "team"`)
+	assert.Nil(t, labels)
+}
+
+func TestInvalidLabelKeyOrValue(t *testing.T) {
+	var tests = []struct {
+		label         string
+		expectedError string
+	}{
+		{"Team=infra", `invalid GCE label key "Team", keys must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores or dashes`},
+		{"1team=infra", `invalid GCE label key "1team", keys must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores or dashes`},
+		{strings.Repeat("k", 64) + "=infra", `invalid GCE label key "` + strings.Repeat("k", 64) + `", keys must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores or dashes`},
+		{"team=Infra", `invalid GCE label value "Infra", values must contain at most 63 lowercase letters, digits, underscores or dashes`},
+		{"team=" + strings.Repeat("v", 64), `invalid GCE label value "` + strings.Repeat("v", 64) + `", values must contain at most 63 lowercase letters, digits, underscores or dashes`},
+	}
+
+	for _, test := range tests {
+		labels, err := parseLabels(&Driver{Labels: []string{test.label}})
+
+		assert.EqualError(t, err, test.expectedError)
+		assert.Nil(t, labels)
+	}
+}
+
+func TestLongestValidLabel(t *testing.T) {
+	key := "k" + strings.Repeat("_", 62)
+	value := strings.Repeat("9", 63)
+
+	labels, err := parseLabels(&Driver{Labels: []string{key + "=" + value}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{key: value}, labels)
+}
+
 func TestPortsUsed(t *testing.T) {
 	var tests = []struct {
 		description   string
@@ -31,6 +31,7 @@ type Driver struct {
 	DiskSize          int
 	Project           string
 	Tags              string
+	Labels            []string
 	UseExisting       bool
 	OpenPorts         []string
 }
@@ -133,6 +134,10 @@ func (d *Driver) GetCreateFlags() []mcnflag.Flag {
 			EnvVar: "GOOGLE_TAGS",
 			Value:  "",
 		},
+		mcnflag.StringSliceFlag{
+			Name:  "google-label",
+			Usage: "GCE Instance Label (e.g. key=value), can be specified multiple times",
+		},
 		mcnflag.BoolFlag{
 			Name:   "google-use-internal-ip",
 			Usage:  "Use internal GCE Instance IP rather than public one",
@@ -217,6 +222,10 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 		d.ServiceAccount = flags.String("google-service-account")
 		d.Scopes = flags.String("google-scopes")
 		d.Tags = flags.String("google-tags")
+		d.Labels = flags.StringSlice("google-label")
+		if _, err := parseLabels(d); err != nil {
+			return err
+		}
 		d.OpenPorts = flags.StringSlice("google-open-port")
 	}
 	d.SSHUser = flags.String("google-username")
@@ -47,6 +47,7 @@ type Driver struct {
 	ConfigDriveISO string
 	ConfigDriveURL string
 	NoShare        bool
+	ShareFolder    string
 }
 
 const (
@@ -108,6 +109,11 @@ func (d *Driver) GetCreateFlags() []mcnflag.Flag {
 			Name:   "vmwarefusion-no-share",
 			Usage:  "Disable the mount of your home directory",
 		},
+		mcnflag.StringFlag{
+			EnvVar: "FUSION_SHARE_FOLDER",
+			Name:   "vmwarefusion-share-folder",
+			Usage:  "Mount the specified directory instead of the default home location. Format: dir:name",
+		},
 	}
 }
 
@@ -155,6 +161,11 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 	d.SSHPassword = flags.String("vmwarefusion-ssh-password")
 	d.SSHPort = 22
 	d.NoShare = flags.Bool("vmwarefusion-no-share")
+	d.ShareFolder = flags.String("vmwarefusion-share-folder")
+
+	if _, _, err := d.shareFolder(); err != nil {
+		return err
+	}
 
 	// We support a maximum of 16 cpu to be consistent with Virtual Hardware 10
 	// specs.
@@ -386,15 +397,11 @@ func (d *Driver) Create() error {
 		return err
 	}
 
-	var shareName, shareDir string // TODO configurable at some point
-	switch runtime.GOOS {
-	case "darwin":
-		shareName = "Users"
-		shareDir = "/Users"
-		// TODO "linux" and "windows"
+	shareDir, shareName, err := d.shareFolder()
+	if err != nil {
+		return err
 	}
-
-	if shareDir != "" && !d.NoShare {
+	if shareDir != "" {
 		if _, err := os.Stat(shareDir); err != nil && !os.IsNotExist(err) {
 			return err
 		} else if !os.IsNotExist(err) {
@@ -403,7 +410,7 @@ func (d *Driver) Create() error {
 			if err != nil {
 				return err
 			}
-			command := "([ ! -d " + shareDir + " ]&& sudo mkdir " + shareDir + "; sudo mount --bind /mnt/hgfs/" + shareDir + " " + shareDir + ") || ([ -f /usr/local/bin/vmhgfs-fuse ]&& sudo /usr/local/bin/vmhgfs-fuse -o allow_other .host:/" + shareName + " " + shareDir + ") || sudo mount -t vmhgfs -o uid=$(id -u),gid=$(id -g) .host:/" + shareName + " " + shareDir
+			command := shareMountCommand(shareDir, shareName, "-o allow_other")
 			_, _, err = vmrun("-gu", B2DUser, "-gp", B2DPass, "runScriptInGuest", d.vmxPath(), "/bin/sh", command)
 			if err != nil {
 				return err
@@ -423,20 +430,16 @@ func (d *Driver) Start() error {
 	}
 
 	log.Debugf("Mounting Shared Folders...")
-	var shareName, shareDir string // TODO configurable at some point
-	switch runtime.GOOS {
-	case "darwin":
-		shareName = "Users"
-		shareDir = "/Users"
-		// TODO "linux" and "windows"
+	shareDir, shareName, err := d.shareFolder()
+	if err != nil {
+		return err
 	}
-
 	if shareDir != "" {
 		if _, err := os.Stat(shareDir); err != nil && !os.IsNotExist(err) {
 			return err
 		} else if !os.IsNotExist(err) {
 			// create mountpoint and mount shared folder
-			command := "([ ! -d " + shareDir + " ]&& sudo mkdir " + shareDir + "; sudo mount --bind /mnt/hgfs/" + shareDir + " " + shareDir + ") || ([ -f /usr/local/bin/vmhgfs-fuse ]&& sudo /usr/local/bin/vmhgfs-fuse -o nonempty -o allow_other .host:/" + shareName + " " + shareDir + ") || sudo mount -t vmhgfs -o uid=$(id -u),gid=$(id -g) .host:/" + shareName + " " + shareDir
+			command := shareMountCommand(shareDir, shareName, "-o nonempty -o allow_other")
 			vmrun("-gu", B2DUser, "-gp", B2DPass, "runScriptInGuest", d.vmxPath(), "/bin/sh", command)
 		}
 	}
@@ -444,6 +447,41 @@ func (d *Driver) Start() error {
 	return nil
 }
 
+// shareFolder returns the host directory to mount in the VM and the name of
+// its share, or an empty directory if nothing should be shared.
+func (d *Driver) shareFolder() (string, string, error) {
+	if d.NoShare {
+		return "", "", nil
+	}
+	if d.ShareFolder != "" {
+		return parseShareFolder(d.ShareFolder)
+	}
+	return "/Users", "Users", nil
+}
+
+func parseShareFolder(shareFolder string) (string, string, error) {
+	split := strings.Split(shareFolder, ":")
+	shareDir := strings.Join(split[:len(split)-1], ":")
+	shareName := split[len(split)-1]
+	if shareDir == "" || shareName == "" {
+		return "", "", fmt.Errorf("invalid --vmwarefusion-share-folder %q, expected format dir:name", shareFolder)
+	}
+	return shareDir, shareName, nil
+}
+
+// shareMountCommand returns the guest script that creates the mount point for
+// shareDir and mounts the shared folder shareName on it.
+func shareMountCommand(shareDir, shareName, fuseOptions string) string {
+	dir := shellQuote(shareDir)
+	return "([ ! -d " + dir + " ]&& sudo mkdir -p " + dir + "; sudo mount --bind " + shellQuote("/mnt/hgfs/"+shareName) + " " + dir + ") || ([ -f /usr/local/bin/vmhgfs-fuse ]&& sudo /usr/local/bin/vmhgfs-fuse " + fuseOptions + " " + shellQuote(".host:/"+shareName) + " " + dir + ") || sudo mount -t vmhgfs -o uid=$(id -u),gid=$(id -g) " + shellQuote(".host:/"+shareName) + " " + dir
+}
+
+// shellQuote wraps s in single quotes so that /bin/sh treats it as one
+// literal word.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
 func (d *Driver) Stop() error {
 	_, _, err := vmrun("stop", d.vmxPath(), "nogui")
 	return err
@@ -20,3 +20,62 @@ func TestSetConfigFromFlags(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, checkFlags.InvalidFlags)
 }
+
+func TestShareFolder(t *testing.T) {
+	cases := []struct {
+		driver       *Driver
+		expectedDir  string
+		expectedName string
+		expectedErr  string
+	}{
+		{&Driver{}, "/Users", "Users", ""},
+		{&Driver{NoShare: true}, "", "", ""},
+		{&Driver{ShareFolder: "/Volumes/data:data"}, "/Volumes/data", "data", ""},
+		{&Driver{NoShare: true, ShareFolder: "/Volumes/data:data"}, "", "", ""},
+		{&Driver{ShareFolder: "/Volumes/my data:my data"}, "/Volumes/my data", "my data", ""},
+		{&Driver{ShareFolder: "/Volumes/data"}, "", "", `invalid --vmwarefusion-share-folder "/Volumes/data", expected format dir:name`},
+		{&Driver{ShareFolder: "/Volumes/data:"}, "", "", `invalid --vmwarefusion-share-folder "/Volumes/data:", expected format dir:name`},
+		{&Driver{ShareFolder: ":data"}, "", "", `invalid --vmwarefusion-share-folder ":data", expected format dir:name`},
+	}
+
+	for _, c := range cases {
+		dir, name, err := c.driver.shareFolder()
+
+		assert.Equal(t, c.expectedDir, dir)
+		assert.Equal(t, c.expectedName, name)
+		if c.expectedErr == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, c.expectedErr)
+		}
+	}
+}
+
+func TestSetConfigFromFlagsInvalidShareFolder(t *testing.T) {
+	driver := NewDriver("default", "path")
+
+	checkFlags := &drivers.CheckDriverOptions{
+		FlagsValues: map[string]interface{}{
+			"vmwarefusion-share-folder": "/Volumes/data",
+		},
+		CreateFlags: driver.GetCreateFlags(),
+	}
+
+	err := driver.SetConfigFromFlags(checkFlags)
+
+	assert.EqualError(t, err, `invalid --vmwarefusion-share-folder "/Volumes/data", expected format dir:name`)
+}
+
+func TestShareMountCommand(t *testing.T) {
+	command := shareMountCommand("/Volumes/data", "data", "-o allow_other")
+
+	assert.Equal(t, "([ ! -d '/Volumes/data' ]&& sudo mkdir -p '/Volumes/data'; sudo mount --bind '/mnt/hgfs/data' '/Volumes/data') || ([ -f /usr/local/bin/vmhgfs-fuse ]&& sudo /usr/local/bin/vmhgfs-fuse -o allow_other '.host:/data' '/Volumes/data') || sudo mount -t vmhgfs -o uid=$(id -u),gid=$(id -g) '.host:/data' '/Volumes/data'", command)
+}
+
+func TestShareMountCommandQuotesPaths(t *testing.T) {
+	command := shareMountCommand("/Volumes/my data", "it's; $(reboot)", "-o allow_other")
+
+	assert.Contains(t, command, "sudo mkdir -p '/Volumes/my data';")
+	assert.Contains(t, command, `'/mnt/hgfs/it'\''s; $(reboot)'`)
+	assert.Contains(t, command, `'.host:/it'\''s; $(reboot)' '/Volumes/my data'`)
+}
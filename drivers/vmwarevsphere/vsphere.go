@@ -226,6 +226,22 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 
 	d.ISO = d.ResolveStorePath(isoFilename)
 
+	return d.checkConfig()
+}
+
+const errorMandatoryEnvOrOption = "%s must be specified either using the environment variable %s or the CLI option %s"
+
+func (d *Driver) checkConfig() error {
+	if d.IP == "" {
+		return fmt.Errorf(errorMandatoryEnvOrOption, "vCenter or ESXi host", "VSPHERE_VCENTER", "--vmwarevsphere-vcenter")
+	}
+	if d.Username == "" {
+		return fmt.Errorf(errorMandatoryEnvOrOption, "Username", "VSPHERE_USERNAME", "--vmwarevsphere-username")
+	}
+	if d.Password == "" {
+		return fmt.Errorf(errorMandatoryEnvOrOption, "Password", "VSPHERE_PASSWORD", "--vmwarevsphere-password")
+	}
+
 	return nil
 }
 
@@ -11,7 +11,11 @@ func TestSetConfigFromFlags(t *testing.T) {
 	driver := NewDriver("default", "path")
 
 	checkFlags := &drivers.CheckDriverOptions{
-		FlagsValues: map[string]interface{}{},
+		FlagsValues: map[string]interface{}{
+			"vmwarevsphere-vcenter":  "vcenter.local",
+			"vmwarevsphere-username": "user",
+			"vmwarevsphere-password": "pass",
+		},
 		CreateFlags: driver.GetCreateFlags(),
 	}
 
@@ -20,3 +24,41 @@ func TestSetConfigFromFlags(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, checkFlags.InvalidFlags)
 }
+
+func TestSetConfigFromFlagsRequiresCredentials(t *testing.T) {
+	cases := []struct {
+		flags       map[string]interface{}
+		expectedErr string
+	}{
+		{
+			map[string]interface{}{},
+			"vCenter or ESXi host must be specified either using the environment variable VSPHERE_VCENTER or the CLI option --vmwarevsphere-vcenter",
+		},
+		{
+			map[string]interface{}{
+				"vmwarevsphere-vcenter": "vcenter.local",
+			},
+			"Username must be specified either using the environment variable VSPHERE_USERNAME or the CLI option --vmwarevsphere-username",
+		},
+		{
+			map[string]interface{}{
+				"vmwarevsphere-vcenter":  "vcenter.local",
+				"vmwarevsphere-username": "user",
+			},
+			"Password must be specified either using the environment variable VSPHERE_PASSWORD or the CLI option --vmwarevsphere-password",
+		},
+	}
+
+	for _, c := range cases {
+		driver := NewDriver("default", "path")
+
+		checkFlags := &drivers.CheckDriverOptions{
+			FlagsValues: c.flags,
+			CreateFlags: driver.GetCreateFlags(),
+		}
+
+		err := driver.SetConfigFromFlags(checkFlags)
+
+		assert.EqualError(t, err, c.expectedErr)
+	}
+}
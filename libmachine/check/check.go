@@ -3,8 +3,8 @@ package check
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
-	"strings"
 
 	"github.com/docker/machine/libmachine/auth"
 	"github.com/docker/machine/libmachine/cert"
@@ -88,7 +88,6 @@ func checkCert(hostURL string, authOptions *auth.Options) error {
 	return nil
 }
 
-// TODO: This could use a unit test.
 func parseSwarm(hostURL string, h *host.Host) (string, error) {
 	swarmOptions := h.HostOptions.SwarmOptions
 
@@ -100,8 +99,10 @@ func parseSwarm(hostURL string, h *host.Host) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("There was an error parsing the url: %s", err)
 	}
-	parts := strings.Split(u.Host, ":")
-	swarmPort := parts[1]
+	_, swarmPort, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return "", fmt.Errorf("There was an error parsing the url: %s", err)
+	}
 
 	// get IP of machine to replace in case swarm host is 0.0.0.0
 	mURL, err := url.Parse(hostURL)
@@ -109,10 +110,12 @@ func parseSwarm(hostURL string, h *host.Host) (string, error) {
 		return "", fmt.Errorf("There was an error parsing the url: %s", err)
 	}
 
-	mParts := strings.Split(mURL.Host, ":")
-	machineIP := mParts[0]
+	machineIP, _, err := net.SplitHostPort(mURL.Host)
+	if err != nil {
+		return "", fmt.Errorf("There was an error parsing the url: %s", err)
+	}
 
-	hostURL = fmt.Sprintf("tcp://%s:%s", machineIP, swarmPort)
+	hostURL = fmt.Sprintf("tcp://%s", net.JoinHostPort(machineIP, swarmPort))
 
 	return hostURL, nil
 }
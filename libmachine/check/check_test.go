@@ -8,6 +8,8 @@ import (
 
 	"github.com/docker/machine/libmachine/auth"
 	"github.com/docker/machine/libmachine/cert"
+	"github.com/docker/machine/libmachine/host"
+	"github.com/docker/machine/libmachine/swarm"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,3 +60,45 @@ func TestCheckCert(t *testing.T) {
 		assert.Equal(t, c.expectedErr, err)
 	}
 }
+
+func TestParseSwarm(t *testing.T) {
+	cases := []struct {
+		hostURL     string
+		swarmHost   string
+		expectedURL string
+	}{
+		{"tcp://192.168.99.100:2376", "tcp://0.0.0.0:3376", "tcp://192.168.99.100:3376"},
+		{"tcp://[2001:db8::1]:2376", "tcp://0.0.0.0:3376", "tcp://[2001:db8::1]:3376"},
+		{"tcp://[2001:db8::1]:2376", "tcp://[::]:3377", "tcp://[2001:db8::1]:3377"},
+	}
+
+	for _, c := range cases {
+		h := &host.Host{
+			Name: "foo",
+			HostOptions: &host.Options{
+				SwarmOptions: &swarm.Options{
+					Master: true,
+					Host:   c.swarmHost,
+				},
+			},
+		}
+
+		swarmURL, err := parseSwarm(c.hostURL, h)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.expectedURL, swarmURL)
+	}
+}
+
+func TestParseSwarmNotMaster(t *testing.T) {
+	h := &host.Host{
+		Name: "foo",
+		HostOptions: &host.Options{
+			SwarmOptions: &swarm.Options{},
+		},
+	}
+
+	_, err := parseSwarm("tcp://192.168.99.100:2376", h)
+
+	assert.EqualError(t, err, `"foo" is not a swarm master. The --swarm flag is intended for use with swarm masters`)
+}
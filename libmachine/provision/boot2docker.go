@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"path"
+	"strconv"
 	"text/template"
 	"time"
 
@@ -197,7 +198,7 @@ func (provisioner *Boot2DockerProvisioner) AttemptIPContact(dockerPort int) {
 		return
 	}
 
-	if conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", ip, dockerPort), 5*time.Second); err != nil {
+	if conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip, strconv.Itoa(dockerPort)), 5*time.Second); err != nil {
 		log.Warnf(`
 This machine has been allocated an IP address, but Docker Machine could not
 reach it successfully.
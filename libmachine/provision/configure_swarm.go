@@ -2,6 +2,7 @@ package provision
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 	"strings"
@@ -26,38 +27,24 @@ func configureSwarm(p Provisioner, swarmOptions swarm.Options, authOptions auth.
 		return err
 	}
 
-	u, err := url.Parse(swarmOptions.Host)
+	engineURL, err := p.GetDriver().GetURL()
 	if err != nil {
 		return err
 	}
 
-	enginePort := engine.DefaultPort
-	engineURL, err := p.GetDriver().GetURL()
+	advertiseInfo, port, err := swarmAddresses(ip, engineURL, swarmOptions.Host)
 	if err != nil {
 		return err
 	}
 
-	parts := strings.Split(engineURL, ":")
-	if len(parts) == 3 {
-		dPort, err := strconv.Atoi(parts[2])
-		if err != nil {
-			return err
-		}
-		enginePort = dPort
-	}
-
-	parts = strings.Split(u.Host, ":")
-	port := parts[1]
-
 	dockerDir := p.GetDockerOptionsDir()
 	dockerHost := &mcndockerclient.RemoteDocker{
-		HostURL:    fmt.Sprintf("tcp://%s:%d", ip, enginePort),
+		HostURL:    fmt.Sprintf("tcp://%s", advertiseInfo),
 		AuthOption: &authOptions,
 	}
-	advertiseInfo := fmt.Sprintf("%s:%d", ip, enginePort)
 
 	if swarmOptions.Master {
-		advertiseMasterInfo := fmt.Sprintf("%s:%s", ip, "3376")
+		advertiseMasterInfo := net.JoinHostPort(ip, "3376")
 		cmd := fmt.Sprintf("manage --tlsverify --tlscacert=%s --tlscert=%s --tlskey=%s -H %s --strategy %s --advertise %s",
 			authOptions.CaCertRemotePath,
 			authOptions.ServerCertRemotePath,
@@ -147,3 +134,31 @@ func configureSwarm(p Provisioner, swarmOptions swarm.Options, authOptions auth.
 	}
 	return nil
 }
+
+// swarmAddresses returns the engine address the swarm agent advertises for a
+// machine at ip, and the port of the swarm manager taken from swarmHost.
+func swarmAddresses(ip, engineURL, swarmHost string) (string, string, error) {
+	u, err := url.Parse(swarmHost)
+	if err != nil {
+		return "", "", err
+	}
+	_, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return "", "", err
+	}
+
+	enginePort := engine.DefaultPort
+	eu, err := url.Parse(engineURL)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ePort, err := net.SplitHostPort(eu.Host); err == nil {
+		dPort, err := strconv.Atoi(ePort)
+		if err != nil {
+			return "", "", err
+		}
+		enginePort = dPort
+	}
+
+	return net.JoinHostPort(ip, strconv.Itoa(enginePort)), port, nil
+}
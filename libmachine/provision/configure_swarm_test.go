@@ -0,0 +1,81 @@
+package provision
+
+import (
+	"testing"
+
+	"github.com/docker/machine/drivers/fakedriver"
+	"github.com/docker/machine/libmachine/auth"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/docker/machine/libmachine/swarm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwarmAddresses(t *testing.T) {
+	var tests = []struct {
+		ip                string
+		engineURL         string
+		swarmHost         string
+		expectedAdvertise string
+		expectedPort      string
+	}{
+		{"10.0.0.1", "tcp://10.0.0.1:2376", "tcp://0.0.0.0:3376", "10.0.0.1:2376", "3376"},
+		{"10.0.0.1", "tcp://10.0.0.1:4243", "tcp://0.0.0.0:3376", "10.0.0.1:4243", "3376"},
+		{"10.0.0.1", "tcp://10.0.0.1", "tcp://0.0.0.0:3376", "10.0.0.1:2376", "3376"},
+		{"2001:db8::1", "tcp://[2001:db8::1]:4243", "tcp://[::]:3376", "[2001:db8::1]:4243", "3376"},
+		{"2001:db8::1", "tcp://[2001:db8::1]", "tcp://[::]:3377", "[2001:db8::1]:2376", "3377"},
+	}
+
+	for _, test := range tests {
+		advertise, port, err := swarmAddresses(test.ip, test.engineURL, test.swarmHost)
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedAdvertise, advertise)
+		assert.Equal(t, test.expectedPort, port)
+	}
+}
+
+func TestSwarmAddressesMissingSwarmPort(t *testing.T) {
+	_, _, err := swarmAddresses("2001:db8::1", "tcp://[2001:db8::1]:2376", "tcp://[::]")
+
+	assert.Error(t, err)
+}
+
+func TestConfigureSwarmIPv6(t *testing.T) {
+	p := &fakeProvisioner{GenericProvisioner{
+		Driver: &ipv6Driver{
+			Driver: &fakedriver.Driver{
+				MockState: state.Running,
+				MockIP:    "2001:db8::1",
+			},
+			url: "tcp://[2001:db8::1]:2376",
+		},
+	}}
+	swarmOptions := swarm.Options{
+		IsSwarm: true,
+		Host:    "tcp://[::]:3376",
+	}
+
+	err := configureSwarm(p, swarmOptions, auth.Options{})
+
+	assert.NoError(t, err)
+}
+
+func TestConfigureSwarmIPv6MissingPort(t *testing.T) {
+	p := &fakeProvisioner{GenericProvisioner{
+		Driver: &ipv6Driver{
+			Driver: &fakedriver.Driver{
+				MockState: state.Running,
+				MockIP:    "2001:db8::1",
+			},
+			url: "tcp://[2001:db8::1]:2376",
+		},
+	}}
+	swarmOptions := swarm.Options{
+		IsSwarm: true,
+		Host:    "tcp://[2001:db8::1]",
+	}
+
+	err := configureSwarm(p, swarmOptions, auth.Options{})
+
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package provision
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"path"
 	"path/filepath"
@@ -165,9 +166,8 @@ func ConfigureAuth(p Provisioner) error {
 		return err
 	}
 	dockerPort := engine.DefaultPort
-	parts := strings.Split(u.Host, ":")
-	if len(parts) == 2 {
-		dPort, err := strconv.Atoi(parts[1])
+	if _, port, err := net.SplitHostPort(u.Host); err == nil {
+		dPort, err := strconv.Atoi(port)
 		if err != nil {
 			return err
 		}
@@ -1,17 +1,25 @@
 package provision
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/docker/machine/drivers/fakedriver"
 	"github.com/docker/machine/libmachine/auth"
+	"github.com/docker/machine/libmachine/cert"
 	"github.com/docker/machine/libmachine/engine"
 	"github.com/docker/machine/libmachine/provision/pkgaction"
 	"github.com/docker/machine/libmachine/provision/provisiontest"
 	"github.com/docker/machine/libmachine/provision/serviceaction"
+	"github.com/docker/machine/libmachine/state"
 	"github.com/docker/machine/libmachine/swarm"
 	"github.com/stretchr/testify/assert"
 )
@@ -282,3 +290,99 @@ func TestDockerClientVersion(t *testing.T) {
 		}
 	}
 }
+
+type ipv6Driver struct {
+	*fakedriver.Driver
+	url string
+}
+
+func (d *ipv6Driver) GetURL() (string, error) {
+	return d.url, nil
+}
+
+type recordingSSHCommander struct {
+	commands []string
+	response string
+}
+
+func (sshCmder *recordingSSHCommander) SSHCommand(args string) (string, error) {
+	sshCmder.commands = append(sshCmder.commands, args)
+	return sshCmder.response, nil
+}
+
+type dockerPortProvisioner struct {
+	fakeProvisioner
+	dockerPort int
+}
+
+func (provisioner *dockerPortProvisioner) GenerateDockerOptions(dockerPort int) (*DockerOptions, error) {
+	provisioner.dockerPort = dockerPort
+	return &DockerOptions{
+		EngineOptions:     "",
+		EngineOptionsPath: "/etc/docker/options",
+	}, nil
+}
+
+func TestConfigureAuthIPv6(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "machine-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	caCertPath := filepath.Join(tmpDir, "ca.pem")
+	caKeyPath := filepath.Join(tmpDir, "ca-key.pem")
+	if err := cert.GenerateCACertificate(caCertPath, caKeyPath, "test", 2048); err != nil {
+		t.Fatal(err)
+	}
+	storePath := filepath.Join(tmpDir, "machine")
+	if err := os.Mkdir(storePath, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	sshCmder := &recordingSSHCommander{
+		response: "tcp6       0      0 :::3376                 :::*                    LISTEN",
+	}
+	p := &dockerPortProvisioner{
+		fakeProvisioner: fakeProvisioner{GenericProvisioner{
+			SSHCommander: sshCmder,
+			Driver: &ipv6Driver{
+				Driver: &fakedriver.Driver{
+					MockState: state.Running,
+					MockIP:    "2001:db8::1",
+					MockName:  "test",
+				},
+				url: "tcp://[2001:db8::1]:3376",
+			},
+			AuthOptions: auth.Options{
+				StorePath:            storePath,
+				CaCertPath:           caCertPath,
+				CaPrivateKeyPath:     caKeyPath,
+				ClientCertPath:       caCertPath,
+				ClientKeyPath:        caKeyPath,
+				ServerCertPath:       filepath.Join(storePath, "server.pem"),
+				ServerKeyPath:        filepath.Join(storePath, "server-key.pem"),
+				CaCertRemotePath:     "/test/ca-cert",
+				ServerCertRemotePath: "/test/server-cert",
+				ServerKeyRemotePath:  "/test/server-key",
+			},
+		}},
+	}
+
+	err = ConfigureAuth(p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3376, p.dockerPort)
+
+	serverCert, err := ioutil.ReadFile(filepath.Join(storePath, "server.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(serverCert)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, parsed.IPAddresses, 1)
+	assert.True(t, parsed.IPAddresses[0].Equal(net.ParseIP("2001:db8::1")))
+}
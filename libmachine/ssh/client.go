@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/term"
 	"github.com/docker/machine/libmachine/log"
@@ -58,6 +59,10 @@ type ClientType string
 
 const (
 	maxDialAttempts = 10
+
+	// connectTimeout matches the ConnectTimeout passed to the external client
+	// so that both implementations give up on unreachable hosts alike.
+	connectTimeout = 10 * time.Second
 )
 
 const (
@@ -155,6 +160,7 @@ func NewNativeConfig(user string, auth *Auth) (ssh.ClientConfig, error) {
 		User:            user,
 		Auth:            authMethods,
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         connectTimeout,
 	}, nil
 }
 
@@ -104,3 +104,11 @@ func TestNewExternalClient(t *testing.T) {
 		}
 	}
 }
+
+func TestNewNativeConfigSetsConnectTimeout(t *testing.T) {
+	config, err := NewNativeConfig("docker", &Auth{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "docker", config.User)
+	assert.Equal(t, connectTimeout, config.Timeout)
+}